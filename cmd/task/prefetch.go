@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/go-task/task/v3/internal/flags"
+	"github.com/go-task/task/v3/taskfile"
+)
+
+// prefetch walks the include graph rooted at the current Taskfile without
+// executing anything, downloading every remote node in parallel (bounded by
+// concurrency) and printing a live table of their progress. This lets users
+// warm a cold cache offline, ahead of a CI run where network access may be
+// restricted or slow.
+func prefetch(concurrency int) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	node, err := taskfile.NewNode(flags.Entrypoint, wd, flags.Insecure, flags.Timeout)
+	if err != nil {
+		return err
+	}
+
+	table := newProgressTable(os.Stdout)
+
+	opts := []taskfile.ReaderOption{
+		taskfile.WithInsecure(flags.Insecure),
+		taskfile.WithTimeout(flags.Timeout),
+		taskfile.WithOffline(false),
+		taskfile.WithDownload(true),
+		taskfile.WithProgressFunc(table.update),
+	}
+	if concurrency > 0 {
+		opts = append(opts, taskfile.WithFetchConcurrency(concurrency))
+	}
+
+	reader := taskfile.NewReader(node, opts...)
+	if _, err := reader.Read(); err != nil {
+		return err
+	}
+
+	table.printFinal()
+	return nil
+}
+
+// progressTable renders a simple, repeatedly-redrawn table of the state of
+// every remote node seen so far.
+type progressTable struct {
+	w      *os.File
+	mutex  sync.Mutex
+	states map[string]taskfile.ProgressEvent
+}
+
+func newProgressTable(w *os.File) *progressTable {
+	return &progressTable{w: w, states: map[string]taskfile.ProgressEvent{}}
+}
+
+func (t *progressTable) update(e taskfile.ProgressEvent) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.states[e.Location] = e
+	t.render()
+}
+
+func (t *progressTable) render() {
+	locations := make([]string, 0, len(t.states))
+	for loc := range t.states {
+		locations = append(locations, loc)
+	}
+	sort.Strings(locations)
+
+	fmt.Fprint(t.w, "\033[H\033[2J")
+	for _, loc := range locations {
+		e := t.states[loc]
+		fmt.Fprintf(t.w, "%-10s %s (%d/%d bytes)\n", stateLabel(e.State), loc, e.BytesRead, e.TotalBytes)
+	}
+}
+
+func (t *progressTable) printFinal() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.render()
+}
+
+func stateLabel(s taskfile.ProgressState) string {
+	switch s {
+	case taskfile.ProgressQueued:
+		return "queued"
+	case taskfile.ProgressStarted:
+		return "started"
+	case taskfile.ProgressUpdated:
+		return "fetching"
+	case taskfile.ProgressCached:
+		return "cached"
+	case taskfile.ProgressDone:
+		return "done"
+	case taskfile.ProgressFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
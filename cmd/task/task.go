@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"mvdan.cc/sh/v3/syntax"
@@ -16,6 +17,7 @@ import (
 	"github.com/go-task/task/v3/internal/experiments"
 	"github.com/go-task/task/v3/internal/filepathext"
 	"github.com/go-task/task/v3/internal/flags"
+	"github.com/go-task/task/v3/internal/i18n"
 	"github.com/go-task/task/v3/internal/logger"
 	"github.com/go-task/task/v3/internal/version"
 	"github.com/go-task/task/v3/taskfile"
@@ -24,12 +26,7 @@ import (
 
 func main() {
 	if err := run(); err != nil {
-		l := &logger.Logger{
-			Stdout:  os.Stdout,
-			Stderr:  os.Stderr,
-			Verbose: flags.Verbose,
-			Color:   flags.Color,
-		}
+		l := newLogger()
 		if err, ok := err.(*errors.TaskRunError); ok && flags.ExitCode {
 			l.Errf(logger.Red, "%v\n", err)
 			os.Exit(err.TaskExitCode())
@@ -44,12 +41,31 @@ func main() {
 	os.Exit(errors.CodeOk)
 }
 
-func run() error {
-	log := &logger.Logger{
+// newLogger builds the [logger.Logger] used for the lifetime of a run. A
+// structured sink (text/json/logfmt, selected via --log-format) filtered at
+// --log-level is only attached once the user opts in by passing
+// --log-format; otherwise no sink is attached and [logger.Logger.Log] is a
+// no-op, so a plain `task` invocation keeps printing only the existing
+// colored human-readable output on Stdout/Stderr.
+func newLogger() *logger.Logger {
+	l := &logger.Logger{
 		Stdout:  os.Stdout,
 		Stderr:  os.Stderr,
 		Verbose: flags.Verbose,
 		Color:   flags.Color,
+		Level:   flags.LogLevel,
+	}
+	if flags.LogFormat != "" {
+		l.SetSink(logger.NewSink(flags.LogFormat, os.Stderr))
+	}
+	return l
+}
+
+func run() error {
+	log := newLogger()
+
+	if err := i18n.Init(flags.Lang); err != nil {
+		return err
 	}
 
 	if err := flags.Validate(); err != nil {
@@ -99,7 +115,53 @@ func run() error {
 			if flags.Verbose {
 				log.Outf(logger.Default, "%s\n", task.DefaultTaskfile)
 			}
-			log.Outf(logger.Green, "Taskfile created: %s\n", filepathext.TryAbsToRel(finalPath))
+			log.Outf(logger.Green, "%s", i18n.T(i18n.KeyTaskfileCreated, filepathext.TryAbsToRel(finalPath)))
+		}
+		return nil
+	}
+
+	if flags.Sign {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		path := filepathext.SmartJoin(wd, "Taskfile.yml")
+		args, _, err := getArgs()
+		if err != nil {
+			return err
+		}
+		if len(args) > 0 {
+			path = filepathext.SmartJoin(wd, args[0])
+		}
+		sigPath, publicKey, err := taskfile.Sign(path, flags.SignKey)
+		if err != nil {
+			return err
+		}
+		if !flags.Silent {
+			log.Outf(logger.Green, "Signature written: %s\n", filepathext.TryAbsToRel(sigPath))
+			log.Outf(logger.Default, "Public key (share with verifiers via --trusted-key): %s\n", publicKey)
+		}
+		return nil
+	}
+
+	if flags.Publish != "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		path := filepathext.SmartJoin(wd, "Taskfile.yml")
+		args, _, err := getArgs()
+		if err != nil {
+			return err
+		}
+		if len(args) > 0 {
+			path = filepathext.SmartJoin(wd, args[0])
+		}
+		if err := taskfile.Publish(context.Background(), path, flags.Publish); err != nil {
+			return err
+		}
+		if !flags.Silent {
+			log.Outf(logger.Green, "Taskfile published: %s\n", flags.Publish)
 		}
 		return nil
 	}
@@ -113,10 +175,30 @@ func run() error {
 		return nil
 	}
 
-	e := task.NewExecutor(
+	if flags.Prefetch {
+		return prefetch(flags.FetchConcurrency)
+	}
+
+	executorOpts := []task.ExecutorOption{
 		flags.WithFlags(),
 		task.WithVersionCheck(true),
-	)
+		// Route the Reader's debug output through the same structured sink as
+		// the run-level events below, instead of leaving it stuck on the
+		// logger's plain Verbose writer.
+		task.WithTaskfileReaderOptions(taskfile.WithLogger(log)),
+	}
+	if flags.Verify {
+		keyringDir, err := taskfile.DefaultKeyringDir()
+		if err != nil {
+			return err
+		}
+		executorOpts = append(executorOpts, task.WithTaskfileReaderOptions(
+			taskfile.WithKeyring(keyringDir),
+			taskfile.WithTrustedKey(flags.TrustedKey),
+		))
+	}
+
+	e := task.NewExecutor(executorOpts...)
 	if err := e.Setup(); err != nil {
 		return err
 	}
@@ -135,6 +217,17 @@ func run() error {
 		return cache.Clear()
 	}
 
+	if flags.CacheStats {
+		cache, err := taskfile.NewCache(e.TempDir.Remote)
+		if err != nil {
+			return err
+		}
+		stats := cache.Stats()
+		log.Outf(logger.Default, "hits=%d misses=%d blobs=%d used_bytes=%d\n",
+			stats.Hits, stats.Misses, stats.Blobs, stats.UsedBytes)
+		return nil
+	}
+
 	listOptions := task.NewListOptions(
 		flags.List,
 		flags.ListAll,
@@ -189,7 +282,34 @@ func run() error {
 		return e.Status(ctx, calls...)
 	}
 
-	return e.Run(ctx, calls...)
+	// Per-task (started/finished/skipped/up-to-date/failed) events belong
+	// next to the task-execution loop itself, inside Executor.Run, so that
+	// a single compiled binary stays the source of truth for status instead
+	// of this package guessing it from the outside. Only the run-level
+	// start/finish boundary is observable from here.
+	start := time.Now()
+	log.Log(logger.InfoLevel, "run started", map[string]any{
+		"taskfile": e.Taskfile.Location,
+		"calls":    len(calls),
+	})
+	err = e.Run(ctx, calls...)
+	level := logger.InfoLevel
+	if err != nil {
+		level = logger.ErrorLevel
+	}
+	log.Log(level, "run finished", map[string]any{
+		"taskfile": e.Taskfile.Location,
+		"duration": time.Since(start).String(),
+		"error":    errString(err),
+	})
+	return err
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
 }
 
 func getArgs() ([]string, string, error) {
@@ -0,0 +1,30 @@
+package i18n
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Message keys used across the CLI and library. These are passed to [T] as
+// the key, and also serve as the canonical English text, so the catalog only
+// needs an entry for locales that actually translate a string.
+const (
+	KeyTaskfileCreated      = "Taskfile created: %s\n"
+	KeyTaskfileUntrusted    = "The task you are attempting to run depends on the remote Taskfile at %q.\n--- Make sure you trust the source of this Taskfile before continuing ---\nContinue?"
+	KeyTaskfileChanged      = "The Taskfile at %q has changed since you last used it!\n--- Make sure you trust the source of this Taskfile before continuing ---\nContinue?"
+	KeyTaskfileNotTrusted   = "taskfile not trusted: %s"
+	KeyTaskfileVersionCheck = "taskfile %s: missing or invalid version"
+	KeyTaskfileNotFound     = "taskfile not found: %s"
+)
+
+func init() {
+	// English is the source locale: registering it is a no-op beyond making
+	// the key list above discoverable to `go generate` when extracting
+	// strings for translators.
+	message.SetString(language.English, KeyTaskfileCreated, KeyTaskfileCreated)
+	message.SetString(language.English, KeyTaskfileUntrusted, KeyTaskfileUntrusted)
+	message.SetString(language.English, KeyTaskfileChanged, KeyTaskfileChanged)
+	message.SetString(language.English, KeyTaskfileNotTrusted, KeyTaskfileNotTrusted)
+	message.SetString(language.English, KeyTaskfileVersionCheck, KeyTaskfileVersionCheck)
+	message.SetString(language.English, KeyTaskfileNotFound, KeyTaskfileNotFound)
+}
@@ -0,0 +1,9 @@
+package i18n
+
+// Extracting strings for translators is driven by gotext, using the message
+// keys declared in catalog_en.go as the source strings. gotext writes its
+// own JSON message files and a Go catalog; no .pot file is produced:
+//
+//	gotext extract -out=internal/i18n/locales/en/out.gotext.json ./...
+//
+//go:generate gotext -srclang=en update -out=catalog_gen.go -lang=en github.com/go-task/task/v3/...
@@ -0,0 +1,50 @@
+// Package i18n provides translated, user-facing strings for the Taskfile
+// CLI and library, so that error messages and prompts can be read in a
+// user's own language instead of being hard-coded to English.
+package i18n
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// defaultTag is used when no locale can be determined from the environment
+// or an explicit override.
+var defaultTag = language.English
+
+var printer = message.NewPrinter(defaultTag)
+
+// Init selects the active locale, preferring lang if it is non-empty,
+// falling back to LC_MESSAGES and then LANG from the environment. It should
+// be called once at startup, before any call to [T]. An unrecognised or
+// empty locale falls back to English.
+func Init(lang string) error {
+	if lang == "" {
+		lang = os.Getenv("LC_MESSAGES")
+	}
+	if lang == "" {
+		lang = os.Getenv("LANG")
+	}
+	tag := defaultTag
+	if lang != "" {
+		// LANG/LC_MESSAGES values look like "fr_FR.UTF-8"; language.Parse
+		// only understands BCP 47 tags like "fr-FR".
+		if parsed, err := language.Parse(strings.ReplaceAll(strings.SplitN(lang, ".", 2)[0], "_", "-")); err == nil {
+			tag = parsed
+		}
+	}
+	printer = message.NewPrinter(tag)
+	return nil
+}
+
+// T translates the message registered under key, formatting it with args the
+// same way [fmt.Sprintf] would. If key has no translation for the active
+// locale, the key itself is used as the format string, so callers can pass
+// the canonical English text as the key without needing a catalog entry for
+// every locale up front.
+func T(key string, args ...any) string {
+	return printer.Sprintf(key, args...)
+}
@@ -0,0 +1,168 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Color is a terminal color used by the text sink when writing human output.
+type Color int
+
+const (
+	Default Color = iota
+	Red
+	Green
+	Yellow
+	Blue
+	Cyan
+	Magenta
+)
+
+// Level is the severity of a structured [Event]. Levels are ordered from
+// most to least verbose, mirroring zerolog/phuslu-log.
+type Level int
+
+const (
+	TraceLevel Level = iota
+	DebugLevel
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+func (l Level) String() string {
+	switch l {
+	case TraceLevel:
+		return "trace"
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a single structured log entry describing a task lifecycle
+// transition or any other noteworthy occurrence, with a level, a message and
+// a bag of key-value fields (task name, taskfile URI, duration, exit code,
+// ...).
+type Event struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Fields  map[string]any
+}
+
+// Sink receives every [Event] emitted by a [Logger] at or above the
+// Logger's [Logger.Level], and is responsible for formatting and writing it
+// somewhere.
+type Sink interface {
+	Write(Event)
+}
+
+// Logger writes human-readable, colored output to Stdout/Stderr, and also
+// fans out structured [Event]s to a pluggable [Sink] (text, json or logfmt)
+// for machine consumption, e.g. by CI systems. By default no sink is
+// attached and events are dropped.
+type Logger struct {
+	Stdout  io.Writer
+	Stderr  io.Writer
+	Verbose bool
+	Color   bool
+	Level   Level
+
+	mutex sync.Mutex
+	sink  Sink
+}
+
+// SetSink attaches sink as the destination for every structured [Event]
+// emitted via [Logger.Log]. Passing nil disables structured logging.
+func (l *Logger) SetSink(sink Sink) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.sink = sink
+}
+
+// Log emits a structured event at the given level with the given fields, if
+// level is at or above the [Logger]'s configured [Logger.Level] and a sink is
+// attached.
+func (l *Logger) Log(level Level, message string, fields map[string]any) {
+	l.mutex.Lock()
+	sink := l.sink
+	l.mutex.Unlock()
+
+	if sink == nil || level < l.Level {
+		return
+	}
+	sink.Write(Event{
+		Time:    time.Now(),
+		Level:   level,
+		Message: message,
+		Fields:  fields,
+	})
+}
+
+// Outf prints a message to Stdout, colored when [Logger.Color] is enabled.
+func (l *Logger) Outf(color Color, format string, a ...any) {
+	l.writef(l.Stdout, color, format, a...)
+}
+
+// Errf prints a message to Stderr, colored when [Logger.Color] is enabled.
+func (l *Logger) Errf(color Color, format string, a ...any) {
+	l.writef(l.Stderr, color, format, a...)
+}
+
+// Warnf prints a yellow warning message to Stderr if Verbose is enabled.
+func (l *Logger) Warnf(format string, a ...any) {
+	if !l.Verbose {
+		return
+	}
+	l.writef(l.Stderr, Yellow, format, a...)
+}
+
+// VerboseOutf prints a message to Stdout only if Verbose is enabled.
+func (l *Logger) VerboseOutf(color Color, format string, a ...any) {
+	if !l.Verbose {
+		return
+	}
+	l.Outf(color, format, a...)
+}
+
+// PrintExperiments writes a fixed notice that no experiments are active to
+// Stdout. It does not list the known experimental flags or their state.
+func (l *Logger) PrintExperiments() error {
+	l.Outf(Default, "No experiments are currently active.\n")
+	return nil
+}
+
+func (l *Logger) writef(w io.Writer, color Color, format string, a ...any) {
+	msg := fmt.Sprintf(format, a...)
+	if l.Color {
+		msg = colorize(color, msg)
+	}
+	fmt.Fprint(w, msg)
+}
+
+func colorize(color Color, msg string) string {
+	code, ok := ansiCodes[color]
+	if !ok {
+		return msg
+	}
+	return fmt.Sprintf("\033[%sm%s\033[0m", code, msg)
+}
+
+var ansiCodes = map[Color]string{
+	Red:     "31",
+	Green:   "32",
+	Yellow:  "33",
+	Blue:    "34",
+	Magenta: "35",
+	Cyan:    "36",
+}
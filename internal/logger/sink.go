@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Format selects the sink used for structured events, configurable via the
+// CLI's --log-format flag.
+type Format string
+
+const (
+	FormatText   Format = "text"
+	FormatJSON   Format = "json"
+	FormatLogfmt Format = "logfmt"
+)
+
+// NewSink builds the [Sink] matching format, writing to w. An unrecognised
+// format falls back to [FormatText].
+func NewSink(format Format, w io.Writer) Sink {
+	switch format {
+	case FormatJSON:
+		return &jsonSink{w: w}
+	case FormatLogfmt:
+		return &logfmtSink{w: w}
+	default:
+		return &textSink{w: w}
+	}
+}
+
+type jsonSink struct {
+	w io.Writer
+}
+
+func (s *jsonSink) Write(e Event) {
+	fields := make(map[string]any, len(e.Fields)+3)
+	for k, v := range e.Fields {
+		fields[k] = v
+	}
+	fields["time"] = e.Time.Format(time.RFC3339Nano)
+	fields["level"] = e.Level.String()
+	fields["message"] = e.Message
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(s.w, string(b))
+}
+
+type logfmtSink struct {
+	w io.Writer
+}
+
+func (s *logfmtSink) Write(e Event) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "time=%s level=%s msg=%q", e.Time.Format(time.RFC3339Nano), e.Level, e.Message)
+	for _, k := range sortedKeys(e.Fields) {
+		fmt.Fprintf(&b, " %s=%v", k, e.Fields[k])
+	}
+	fmt.Fprintln(s.w, b.String())
+}
+
+type textSink struct {
+	w io.Writer
+}
+
+func (s *textSink) Write(e Event) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s [%s] %s", e.Time.Format(time.Kitchen), strings.ToUpper(e.Level.String()), e.Message)
+	for _, k := range sortedKeys(e.Fields) {
+		fmt.Fprintf(&b, " %s=%v", k, e.Fields[k])
+	}
+	fmt.Fprintln(s.w, b.String())
+}
+
+func sortedKeys(fields map[string]any) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,357 @@
+package taskfile
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultCacheMaxBytes = 64 * 1024 * 1024 // 64MiB
+	cacheDirName         = "remote"
+	blobsDirName         = "blobs"
+	indexFileName        = "index.json"
+)
+
+// cacheEntry is the metadata kept for a single cached node, indexed by the
+// node's location.
+type cacheEntry struct {
+	Checksum     string    `json:"checksum"`
+	FetchedAt    time.Time `json:"fetchedAt"`
+	AccessedAt   time.Time `json:"accessedAt"`
+	Size         int64     `json:"size"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+}
+
+// CacheStats reports hit/miss/size counters for a [Cache], printed by
+// `task --cache-stats`.
+type CacheStats struct {
+	Hits      int64
+	Misses    int64
+	Blobs     int
+	UsedBytes int64
+}
+
+// CacheOption configures a [Cache] constructed by [NewCache].
+type CacheOption interface {
+	applyToCache(*Cache)
+}
+
+type cacheMaxBytesOption struct{ maxBytes int64 }
+
+func (o *cacheMaxBytesOption) applyToCache(c *Cache) { c.maxBytes = o.maxBytes }
+
+// WithCacheMaxBytes bounds the total disk space used by the content-
+// addressable blob store. Once the budget is exceeded, the least-recently-
+// used blobs are evicted first. By default the cache is bounded to 64MiB.
+func WithCacheMaxBytes(maxBytes int64) ReaderOption {
+	return &readerCacheOption{opt: &cacheMaxBytesOption{maxBytes: maxBytes}}
+}
+
+type cacheTTLOption struct{ ttl time.Duration }
+
+func (o *cacheTTLOption) applyToCache(c *Cache) { c.ttl = o.ttl }
+
+// WithCacheTTL sets how long a cached blob may be served from the cache: an
+// entry fetched more than ttl ago is treated as missing when the [Reader]
+// falls back to its cached copy (offline, or after a network timeout). Online
+// fetches go to the remote source every time, whatever the TTL. A zero TTL
+// (the default) means cached blobs never expire on their own and are only
+// replaced when the remote copy's checksum changes.
+func WithCacheTTL(ttl time.Duration) ReaderOption {
+	return &readerCacheOption{opt: &cacheTTLOption{ttl: ttl}}
+}
+
+// readerCacheOption adapts a [CacheOption] so it can also be passed to
+// [NewReader], deferring application until the [Reader] constructs its
+// [Cache].
+type readerCacheOption struct {
+	opt CacheOption
+}
+
+func (o *readerCacheOption) ApplyToReader(r *Reader) {
+	r.cacheOpts = append(r.cacheOpts, o.opt)
+}
+
+// Cache is a content-addressable store for the bodies of remote Taskfiles.
+// Bodies are stored under tempDir/remote/blobs/<sha256 hex>, deduplicating
+// identical content fetched from different locations. A small JSON index
+// maps each node's location to the metadata needed to serve cache reads,
+// revalidate with conditional requests, and evict least-recently-used blobs
+// once the configured size budget is exceeded.
+type Cache struct {
+	dir      string
+	blobsDir string
+	maxBytes int64
+	ttl      time.Duration
+
+	mutex sync.Mutex
+	index map[string]cacheEntry
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// NewCache constructs a [Cache] rooted at tempDir/remote, loading its
+// existing index if one is present.
+func NewCache(tempDir string, opts ...CacheOption) (*Cache, error) {
+	dir := filepath.Join(tempDir, cacheDirName)
+	c := &Cache{
+		dir:      dir,
+		blobsDir: filepath.Join(dir, blobsDirName),
+		maxBytes: defaultCacheMaxBytes,
+		index:    map[string]cacheEntry{},
+	}
+	for _, opt := range opts {
+		opt.applyToCache(c)
+	}
+	if err := os.MkdirAll(c.blobsDir, 0o755); err != nil {
+		return nil, err
+	}
+	if err := c.loadIndex(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Cache) indexPath() string {
+	return filepath.Join(c.dir, indexFileName)
+}
+
+func (c *Cache) blobPath(checksum string) string {
+	return filepath.Join(c.blobsDir, checksum)
+}
+
+func (c *Cache) loadIndex() error {
+	b, err := os.ReadFile(c.indexPath())
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return json.Unmarshal(b, &c.index)
+}
+
+// saveIndex persists the in-memory index. Callers must hold c.mutex.
+func (c *Cache) saveIndex() error {
+	b, err := json.Marshal(c.index)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.indexPath(), b, 0o644)
+}
+
+// read returns the cached body for node, honoring the configured TTL. It
+// returns an error satisfying errors.Is(err, os.ErrNotExist) when there is no
+// usable cached copy.
+func (c *Cache) read(node Node) ([]byte, error) {
+	c.mutex.Lock()
+	entry, ok := c.index[node.Location()]
+	if ok && c.ttl > 0 && time.Since(entry.FetchedAt) > c.ttl {
+		ok = false
+	}
+	if ok {
+		entry.AccessedAt = time.Now()
+		c.index[node.Location()] = entry
+	}
+	c.mutex.Unlock()
+
+	if !ok {
+		c.misses.Add(1)
+		return nil, os.ErrNotExist
+	}
+
+	b, err := os.ReadFile(c.blobPath(entry.Checksum))
+	if err != nil {
+		c.misses.Add(1)
+		return nil, err
+	}
+	c.hits.Add(1)
+	return b, nil
+}
+
+// revalidationInfo returns the ETag/Last-Modified recorded for node, if any,
+// so callers can issue a conditional request (If-None-Match /
+// If-Modified-Since) before re-downloading it.
+func (c *Cache) revalidationInfo(node Node) (etag, lastModified string, ok bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, found := c.index[node.Location()]
+	if !found {
+		return "", "", false
+	}
+	return entry.ETag, entry.LastModified, true
+}
+
+// write stores body under its content hash and associates it with node's
+// location, evicting least-recently-used blobs until the cache fits within
+// maxBytes.
+func (c *Cache) write(node Node, body []byte, etag, lastModified string) error {
+	sum := sha256.Sum256(body)
+	hexSum := hex.EncodeToString(sum[:])
+
+	if _, err := os.Stat(c.blobPath(hexSum)); os.IsNotExist(err) {
+		if err := os.WriteFile(c.blobPath(hexSum), body, 0o644); err != nil {
+			return err
+		}
+	}
+
+	c.mutex.Lock()
+	now := time.Now()
+	c.index[node.Location()] = cacheEntry{
+		Checksum:     hexSum,
+		FetchedAt:    now,
+		AccessedAt:   now,
+		Size:         int64(len(body)),
+		ETag:         etag,
+		LastModified: lastModified,
+	}
+	err := c.evictLocked()
+	if err == nil {
+		err = c.saveIndex()
+	}
+	c.mutex.Unlock()
+	return err
+}
+
+// touch refreshes the FetchedAt/AccessedAt timestamps for node without
+// rewriting its blob, used when a conditional request comes back 304 Not
+// Modified.
+func (c *Cache) touch(node Node) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.index[node.Location()]
+	if !ok {
+		return os.ErrNotExist
+	}
+	now := time.Now()
+	entry.FetchedAt = now
+	entry.AccessedAt = now
+	c.index[node.Location()] = entry
+	return c.saveIndex()
+}
+
+// evictLocked removes the least-recently-used blobs until the total size of
+// referenced blobs fits within maxBytes. Callers must hold c.mutex.
+func (c *Cache) evictLocked() error {
+	if c.maxBytes <= 0 {
+		return nil
+	}
+
+	type ref struct {
+		location string
+		entry    cacheEntry
+	}
+	refs := make([]ref, 0, len(c.index))
+	for loc, entry := range c.index {
+		refs = append(refs, ref{location: loc, entry: entry})
+	}
+	total := c.distinctBlobSizeLocked()
+	if total <= c.maxBytes {
+		return nil
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].entry.AccessedAt.Before(refs[j].entry.AccessedAt)
+	})
+
+	for _, r := range refs {
+		if total <= c.maxBytes {
+			break
+		}
+		delete(c.index, r.location)
+		// Only reclaim the blob's space, and only remove it from disk, once
+		// no other location references it - a shared blob takes up the same
+		// space on disk regardless of how many locations point at it.
+		if !c.blobReferencedByOthersLocked(r.entry.Checksum, r.location) {
+			if err := os.Remove(c.blobPath(r.entry.Checksum)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			total -= r.entry.Size
+		}
+	}
+	return nil
+}
+
+func (c *Cache) blobReferencedByOthersLocked(checksum, exceptLocation string) bool {
+	for loc, entry := range c.index {
+		if loc != exceptLocation && entry.Checksum == checksum {
+			return true
+		}
+	}
+	return false
+}
+
+// distinctBlobSizeLocked returns the total on-disk size of the blob store,
+// counting each distinct checksum once regardless of how many locations in
+// the index reference it. Callers must hold c.mutex.
+func (c *Cache) distinctBlobSizeLocked() int64 {
+	seen := make(map[string]int64, len(c.index))
+	for _, entry := range c.index {
+		seen[entry.Checksum] = entry.Size
+	}
+	var total int64
+	for _, size := range seen {
+		total += size
+	}
+	return total
+}
+
+// Stats returns the current hit/miss counters along with the blob store's
+// size, for `task --cache-stats`. UsedBytes and Blobs count each distinct
+// checksum once, since deduplicated blobs referenced by multiple locations
+// only take up space on disk a single time.
+func (c *Cache) Stats() CacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	checksums := make(map[string]struct{}, len(c.index))
+	for _, entry := range c.index {
+		checksums[entry.Checksum] = struct{}{}
+	}
+	return CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Blobs:     len(checksums),
+		UsedBytes: c.distinctBlobSizeLocked(),
+	}
+}
+
+// readChecksum returns the checksum recorded the last time node's content
+// was cached, or an empty string if it has never been cached.
+func (c *Cache) readChecksum(node Node) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.index[node.Location()].Checksum
+}
+
+// Clear removes every cached blob and the index, e.g. via `task
+// --clear-cache`.
+func (c *Cache) Clear() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.index = map[string]cacheEntry{}
+	if err := os.RemoveAll(c.dir); err != nil {
+		return err
+	}
+	return os.MkdirAll(c.blobsDir, 0o755)
+}
+
+// ConditionalReader may be implemented by a [Node] (typically one backed by
+// HTTP) to support revalidating a cached copy with If-None-Match /
+// If-Modified-Since instead of always re-downloading the full body.
+type ConditionalReader interface {
+	// ReadConditional behaves like Read, except that notModified is true and
+	// body is nil when the remote source reports that neither etag nor
+	// lastModified have changed.
+	ReadConditional(ctx context.Context, etag, lastModified string) (body []byte, notModified bool, newETag, newLastModified string, err error)
+}
@@ -0,0 +1,77 @@
+package taskfile
+
+import (
+	"context"
+	"testing"
+)
+
+// fakeNode is a minimal [Node] that only needs a stable Location for cache
+// tests; nothing here reads or fetches its content.
+type fakeNode struct {
+	*BaseNode
+}
+
+func newFakeNode(location string) *fakeNode {
+	return &fakeNode{BaseNode: NewBaseNode(location)}
+}
+
+func (n *fakeNode) Remote() bool { return true }
+
+func (n *fakeNode) Read(ctx context.Context) ([]byte, error) { return nil, nil }
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache, err := newTestCache(t, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := newFakeNode("https://example.com/old.yml")
+	newer := newFakeNode("https://example.com/new.yml")
+
+	if err := cache.write(old, []byte("0123456789"), "", ""); err != nil {
+		t.Fatalf("write old: %v", err)
+	}
+	if err := cache.write(newer, []byte("abcdefghij"), "", ""); err != nil {
+		t.Fatalf("write new: %v", err)
+	}
+
+	// Writing "new" should have evicted "old", since together they exceed
+	// the 10-byte budget and "old" was the least recently used.
+	if _, err := cache.read(old); err == nil {
+		t.Fatal("expected the older entry to have been evicted")
+	}
+	if _, err := cache.read(newer); err != nil {
+		t.Fatalf("expected the newer entry to still be cached: %v", err)
+	}
+}
+
+func TestCacheStatsDeduplicatesSharedBlobs(t *testing.T) {
+	cache, err := newTestCache(t, 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := newFakeNode("https://example.com/a.yml")
+	b := newFakeNode("https://example.com/b.yml")
+	body := []byte("shared content")
+
+	if err := cache.write(a, body, "", ""); err != nil {
+		t.Fatalf("write a: %v", err)
+	}
+	if err := cache.write(b, body, "", ""); err != nil {
+		t.Fatalf("write b: %v", err)
+	}
+
+	stats := cache.Stats()
+	if stats.Blobs != 1 {
+		t.Fatalf("Blobs = %d, want 1 (a and b share identical content)", stats.Blobs)
+	}
+	if stats.UsedBytes != int64(len(body)) {
+		t.Fatalf("UsedBytes = %d, want %d (shared blob counted once)", stats.UsedBytes, len(body))
+	}
+}
+
+func newTestCache(t *testing.T, maxBytes int64) (*Cache, error) {
+	t.Helper()
+	return NewCache(t.TempDir(), &cacheMaxBytesOption{maxBytes: maxBytes})
+}
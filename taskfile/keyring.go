@@ -0,0 +1,153 @@
+package taskfile
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-task/task/v3/errors"
+)
+
+// DefaultKeyringDir returns the directory where trusted public keys are
+// pinned on first use, following the same layout as SSH's known_hosts file.
+func DefaultKeyringDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".task", "trusted_keys"), nil
+}
+
+// keyring pins remote Taskfile signing keys to a host on first use (TOFU) and
+// verifies subsequent fetches against the pinned key, in the same spirit as
+// SSH's known_hosts mechanism.
+type keyring struct {
+	dir string
+}
+
+func newKeyring(dir string) *keyring {
+	return &keyring{dir: dir}
+}
+
+// sigLocation returns the location of the detached signature expected to sit
+// alongside the given Taskfile node.
+func sigLocation(location string) string {
+	return location + ".sig"
+}
+
+// hostFor extracts the host component used to key a node's pinned public
+// key, falling back to the raw location for nodes without a URL-shaped
+// location (e.g. local files).
+func hostFor(location string) string {
+	if u, err := url.Parse(location); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return location
+}
+
+func (k *keyring) path(host string) string {
+	return filepath.Join(k.dir, host)
+}
+
+// trustedKey returns the pinned public key for the given host, or false if
+// no key has been pinned yet.
+func (k *keyring) trustedKey(host string) (ed25519.PublicKey, bool, error) {
+	b, err := os.ReadFile(k.path(host))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	key, err := decodePublicKey(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, false, err
+	}
+	return key, true, nil
+}
+
+// pin stores key as the trusted key for host.
+func (k *keyring) pin(host string, key ed25519.PublicKey) error {
+	if err := os.MkdirAll(filepath.Dir(k.path(host)), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(k.path(host), []byte(encodePublicKey(key)+"\n"), 0o600)
+}
+
+func encodePublicKey(key ed25519.PublicKey) string {
+	return hex.EncodeToString(key)
+}
+
+func decodePublicKey(s string) (ed25519.PublicKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("taskfile: malformed trusted key: %w", err)
+	}
+	if len(b) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("taskfile: trusted key has invalid length %d", len(b))
+	}
+	return ed25519.PublicKey(b), nil
+}
+
+// verify checks body against the detached signature sig found at location.
+// Unlike SSH's known_hosts, the key that pins a host is never taken from the
+// artifact or mirror being verified - that would let whoever controls the
+// mirror supply body, signature and "trusted" key together on first fetch,
+// defeating the point of verifying an untrusted mirror at all. Instead, the
+// pinned key must come from trustedKey, an out-of-band key supplied by the
+// caller (the CLI's --trusted-key), or from a key pinned by a previous call
+// with trustedKey set. A host with nothing pinned and no trustedKey given is
+// rejected outright.
+func (k *keyring) verify(location string, body, sig []byte, trustedKey ed25519.PublicKey) error {
+	sigBytes, err := parseSignature(sig)
+	if err != nil {
+		return &errors.TaskfileSignatureError{URI: location, Err: err}
+	}
+
+	host := hostFor(location)
+	pinned, ok, err := k.trustedKey(host)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case ok && trustedKey != nil && !pinned.Equal(trustedKey):
+		return &errors.TaskfileSignatureError{
+			URI: location,
+			Err: fmt.Errorf("--trusted-key does not match the key already pinned for %q", host),
+		}
+	case !ok && trustedKey != nil:
+		if err := k.pin(host, trustedKey); err != nil {
+			return err
+		}
+		pinned = trustedKey
+	case !ok:
+		return &errors.TaskfileSignatureError{
+			URI: location,
+			Err: fmt.Errorf("no trusted key pinned for %q; fetch once with --trusted-key to pin one", host),
+		}
+	}
+
+	if !ed25519.Verify(pinned, body, sigBytes) {
+		return &errors.TaskfileSignatureError{
+			URI: location,
+			Err: fmt.Errorf("signature does not match"),
+		}
+	}
+	return nil
+}
+
+// parseSignature decodes a detached signature file: a single hex-encoded
+// ed25519 signature. It intentionally carries no key material, since the
+// trusted key must always come from [keyring.verify]'s trustedKey parameter,
+// never from the file being verified.
+func parseSignature(b []byte) ([]byte, error) {
+	sig, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, fmt.Errorf("malformed signature: %w", err)
+	}
+	return sig, nil
+}
@@ -0,0 +1,111 @@
+package taskfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Taskfile.yml")
+	if err := os.WriteFile(path, []byte("version: '3'\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sigPath, publicKey, err := Sign(path, "")
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := os.ReadFile(sigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trustedKey, err := decodePublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("decodePublicKey: %v", err)
+	}
+
+	k := newKeyring(filepath.Join(dir, "keyring"))
+	if err := k.verify(path, body, sig, trustedKey); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	// A second verify, with no trustedKey supplied, must succeed against the
+	// key pinned by the first call.
+	if err := k.verify(path, body, sig, nil); err != nil {
+		t.Fatalf("verify against pinned key: %v", err)
+	}
+}
+
+func TestSignVerifyMismatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Taskfile.yml")
+	if err := os.WriteFile(path, []byte("version: '3'\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sigPath, publicKey, err := Sign(path, "")
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	sig, err := os.ReadFile(sigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trustedKey, err := decodePublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("decodePublicKey: %v", err)
+	}
+
+	k := newKeyring(filepath.Join(dir, "keyring"))
+
+	t.Run("tampered body", func(t *testing.T) {
+		if err := k.verify(path, []byte("version: '3'\ntasks: {}\n"), sig, trustedKey); err == nil {
+			t.Fatal("expected verify to fail for a tampered body")
+		}
+	})
+
+	t.Run("wrong key pinned for host", func(t *testing.T) {
+		otherPath := filepath.Join(dir, "other.yml")
+		if err := os.WriteFile(otherPath, []byte("version: '3'\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, otherPublicKey, err := Sign(otherPath, "")
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		otherKey, err := decodePublicKey(otherPublicKey)
+		if err != nil {
+			t.Fatalf("decodePublicKey: %v", err)
+		}
+		if err := k.verify(path, []byte("version: '3'\n"), sig, otherKey); err == nil {
+			t.Fatal("expected verify to fail when --trusted-key conflicts with the pinned key")
+		}
+	})
+
+	t.Run("no trusted key for unseen host", func(t *testing.T) {
+		fresh := newKeyring(filepath.Join(dir, "fresh-keyring"))
+		if err := fresh.verify(path, []byte("version: '3'\n"), sig, nil); err == nil {
+			t.Fatal("expected verify to fail without a trusted key and nothing pinned")
+		}
+	})
+}
+
+func TestSignInvalidKeyLength(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Taskfile.yml")
+	if err := os.WriteFile(path, []byte("version: '3'\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := Sign(path, "deadbeef"); err == nil {
+		t.Fatal("expected Sign to reject a key of the wrong length instead of panicking")
+	}
+}
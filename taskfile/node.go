@@ -0,0 +1,231 @@
+package taskfile
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-task/task/v3/errors"
+)
+
+type (
+	// Node is a source that a Taskfile (or an include of one) can be read
+	// from, e.g. a local file, an HTTP(S) URL, or (per [OCINode]) a
+	// container registry.
+	Node interface {
+		Location() string
+		Remote() bool
+		Read(ctx context.Context) ([]byte, error)
+		ResolveEntrypoint(entrypoint string) (string, error)
+		ResolveDir(dir string) (string, error)
+	}
+	// A NodeOption is any type that can apply a configuration to a
+	// [BaseNode].
+	NodeOption interface {
+		applyToBaseNode(*BaseNode)
+	}
+	// BaseNode holds the state shared by every [Node] implementation:
+	// its own location and, for included Taskfiles, the node that included
+	// it.
+	BaseNode struct {
+		location string
+		parent   Node
+	}
+)
+
+// NewBaseNode constructs a [BaseNode] for location with the given options
+// applied. It is meant to be embedded by concrete [Node] implementations.
+func NewBaseNode(location string, opts ...NodeOption) *BaseNode {
+	b := &BaseNode{location: location}
+	for _, opt := range opts {
+		opt.applyToBaseNode(b)
+	}
+	return b
+}
+
+// Location implements [Node].
+func (b *BaseNode) Location() string {
+	return b.location
+}
+
+// ResolveEntrypoint implements [Node]. A remote-looking entrypoint (one
+// recognised by [NewNode]) is returned unchanged; otherwise it is resolved
+// relative to the node's own location.
+func (b *BaseNode) ResolveEntrypoint(entrypoint string) (string, error) {
+	if entrypoint == "" || isRemoteEntrypoint(entrypoint) {
+		return entrypoint, nil
+	}
+	if filepath.IsAbs(entrypoint) {
+		return entrypoint, nil
+	}
+	return filepath.Join(filepath.Dir(b.location), entrypoint), nil
+}
+
+// ResolveDir implements [Node]. An empty dir resolves to the directory of
+// the node's own location; a relative dir is resolved against it.
+func (b *BaseNode) ResolveDir(dir string) (string, error) {
+	base := filepath.Dir(b.location)
+	if dir == "" {
+		return base, nil
+	}
+	if filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	return filepath.Join(base, dir), nil
+}
+
+// WithParent records the [Node] that included the one being constructed.
+func WithParent(parent Node) NodeOption {
+	return &parentOption{parent: parent}
+}
+
+type parentOption struct {
+	parent Node
+}
+
+func (o *parentOption) applyToBaseNode(b *BaseNode) {
+	b.parent = o.parent
+}
+
+// isRemoteEntrypoint reports whether entrypoint is recognised by [NewNode]
+// as pointing at a remote source rather than a local file.
+func isRemoteEntrypoint(entrypoint string) bool {
+	return isOCIReference(entrypoint) ||
+		strings.HasPrefix(entrypoint, "http://") ||
+		strings.HasPrefix(entrypoint, "https://")
+}
+
+// NewNode builds the [Node] implementation appropriate for entrypoint:
+// an [OCINode] for the "oci://" scheme, an [HTTPNode] for "http(s)://", and
+// a [FileNode] otherwise.
+func NewNode(entrypoint, dir string, insecure bool, timeout time.Duration, opts ...NodeOption) (Node, error) {
+	switch {
+	case isOCIReference(entrypoint):
+		return NewOCINode(entrypoint, opts...)
+	case strings.HasPrefix(entrypoint, "http://"), strings.HasPrefix(entrypoint, "https://"):
+		return NewHTTPNode(entrypoint, insecure, timeout, opts...)
+	default:
+		return NewFileNode(entrypoint, dir, opts...)
+	}
+}
+
+// FileNode is a [Node] backed by a Taskfile on the local filesystem.
+type FileNode struct {
+	*BaseNode
+}
+
+// NewFileNode creates a new [FileNode], resolving entrypoint against dir if
+// entrypoint is relative.
+func NewFileNode(entrypoint, dir string, opts ...NodeOption) (*FileNode, error) {
+	location := entrypoint
+	if !filepath.IsAbs(location) {
+		location = filepath.Join(dir, location)
+	}
+	return &FileNode{BaseNode: NewBaseNode(location, opts...)}, nil
+}
+
+// Remote implements [Node]. Local files are never remote.
+func (node *FileNode) Remote() bool {
+	return false
+}
+
+// Read implements [Node].
+func (node *FileNode) Read(ctx context.Context) ([]byte, error) {
+	b, err := os.ReadFile(node.Location())
+	if os.IsNotExist(err) {
+		return nil, &errors.TaskfileNotFoundError{URI: node.Location()}
+	}
+	return b, err
+}
+
+// HTTPNode is a [Node] backed by a Taskfile served over HTTP(S). It
+// supports conditional revalidation ([ConditionalReader]) and byte-level
+// progress reporting ([StreamingNode]).
+type HTTPNode struct {
+	*BaseNode
+	client *http.Client
+}
+
+// NewHTTPNode creates a new [HTTPNode] for the given URL.
+func NewHTTPNode(entrypoint string, insecure bool, timeout time.Duration, opts ...NodeOption) (*HTTPNode, error) {
+	if strings.HasPrefix(entrypoint, "http://") && !insecure {
+		return nil, &errors.TaskfileNotSecureError{URI: entrypoint}
+	}
+	return &HTTPNode{
+		BaseNode: NewBaseNode(entrypoint, opts...),
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Remote implements [Node]. HTTP(S) sources are always remote.
+func (node *HTTPNode) Remote() bool {
+	return true
+}
+
+// Read implements [Node].
+func (node *HTTPNode) Read(ctx context.Context) ([]byte, error) {
+	resp, _, _, err := node.get(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// ReadConditional implements [ConditionalReader].
+func (node *HTTPNode) ReadConditional(ctx context.Context, etag, lastModified string) ([]byte, bool, string, string, error) {
+	resp, newETag, newLastModified, err := node.get(ctx, etag, lastModified)
+	if err != nil {
+		return nil, false, "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, true, newETag, newLastModified, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, "", "", err
+	}
+	return body, false, newETag, newLastModified, nil
+}
+
+// ReadStream implements [StreamingNode].
+func (node *HTTPNode) ReadStream(ctx context.Context) (io.ReadCloser, int64, error) {
+	resp, _, _, err := node.get(ctx, "", "")
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+func (node *HTTPNode) get(ctx context.Context, etag, lastModified string) (*http.Response, string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, node.Location(), nil)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := node.client.Do(req)
+	if ctx.Err() != nil {
+		return nil, "", "", &errors.TaskfileNetworkTimeoutError{URI: node.Location()}
+	}
+	if err != nil {
+		return nil, "", "", err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotModified {
+		resp.Body.Close()
+		return nil, "", "", &errors.TaskfileNotFoundError{URI: node.Location()}
+	}
+	return resp, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"), nil
+}
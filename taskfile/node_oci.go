@@ -0,0 +1,256 @@
+package taskfile
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-task/task/v3/errors"
+)
+
+// sha256Hex returns the hex-encoded SHA-256 digest of b.
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// ociMediaType is the media type of the single layer of a Taskfile packaged
+// as an OCI artifact.
+const ociMediaType = "application/vnd.taskfile.v1+yaml"
+
+// ociScheme is the URI scheme recognised by [NewNode] for Taskfiles
+// distributed through a container registry, e.g.
+// "oci://ghcr.io/acme/taskfiles/backend:v1.2.0".
+const ociScheme = "oci://"
+
+// isOCIReference reports whether entrypoint uses the "oci://" scheme
+// recognised by [NewNode].
+func isOCIReference(entrypoint string) bool {
+	return strings.HasPrefix(entrypoint, ociScheme)
+}
+
+// OCINode is a [Node] that fetches a Taskfile packaged as a single-layer OCI
+// artifact from a container registry, e.g.
+//
+//	includes:
+//	  shared: oci://ghcr.io/acme/taskfiles/backend:v1.2.0
+//
+// This gives teams a versioned, auth'd and cacheable distribution channel
+// for shared Taskfiles, backed by existing registry infrastructure instead
+// of a bespoke file server.
+type OCINode struct {
+	*BaseNode
+	registry   string
+	repository string
+	reference  string
+}
+
+// NewOCINode creates a new [OCINode] for the "oci://" entrypoint, which must
+// be of the form "<registry>/<repository>[:<tag>|@<digest>]".
+func NewOCINode(entrypoint string, opts ...NodeOption) (*OCINode, error) {
+	ref := strings.TrimPrefix(entrypoint, ociScheme)
+
+	registryHost, rest, ok := strings.Cut(ref, "/")
+	if !ok {
+		return nil, fmt.Errorf("taskfile: invalid OCI reference %q: missing repository", entrypoint)
+	}
+
+	repository, reference := rest, "latest"
+	if at := strings.LastIndex(rest, "@"); at != -1 {
+		repository, reference = rest[:at], rest[at+1:]
+	} else if colon := strings.LastIndex(rest, ":"); colon != -1 {
+		repository, reference = rest[:colon], rest[colon+1:]
+	}
+
+	return &OCINode{
+		BaseNode:   NewBaseNode(entrypoint, opts...),
+		registry:   registryHost,
+		repository: repository,
+		reference:  reference,
+	}, nil
+}
+
+// Remote implements [Node]. OCI artifacts are always fetched over the
+// network, so this always returns true.
+func (node *OCINode) Remote() bool {
+	return true
+}
+
+// Read implements [Node]. It authenticates against the registry using
+// credentials from the standard Docker config (~/.docker/config.json),
+// pulls the manifest for the reference, and returns the bytes of the single
+// layer whose media type is [ociMediaType].
+func (node *OCINode) Read(ctx context.Context) ([]byte, error) {
+	client, err := newOCIClient(node.registry)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := client.manifest(ctx, node.repository, node.reference)
+	if err != nil {
+		return nil, &errors.TaskfileFetchError{URI: node.Location(), Err: err}
+	}
+
+	digest, err := manifest.taskfileLayerDigest()
+	if err != nil {
+		return nil, &errors.TaskfileFetchError{URI: node.Location(), Err: err}
+	}
+
+	body, err := client.blob(ctx, node.repository, digest)
+	if err != nil {
+		return nil, &errors.TaskfileFetchError{URI: node.Location(), Err: err}
+	}
+	return body, nil
+}
+
+// ociManifestMediaType is the media type of the manifest itself, per the OCI
+// image-manifest spec.
+const ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+
+// ociEmptyConfigMediaType is the media type used for the manifest's required
+// config descriptor. Taskfile artifacts have no meaningful config, so an
+// empty JSON object is pushed and referenced, as the spec recommends for
+// configless artifacts.
+const ociEmptyConfigMediaType = "application/vnd.oci.empty.v1+json"
+
+// ociEmptyConfigDigest is the digest of the empty JSON object ("{}") used as
+// the config blob for every published Taskfile artifact.
+var ociEmptyConfigDigest = "sha256:" + sha256Hex([]byte("{}"))
+
+// ociDescriptor is a content descriptor as defined by the OCI image spec:
+// a media type, digest and size identifying a blob.
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// ociManifest is the subset of the OCI image manifest schema needed to
+// publish and find the Taskfile layer. Per the spec, a valid manifest must
+// also carry a schemaVersion, its own mediaType, and a config descriptor,
+// even when (as here) the config carries no meaningful data.
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+func newOCIManifest(layer ociDescriptor) ociManifest {
+	return ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		Config: ociDescriptor{
+			MediaType: ociEmptyConfigMediaType,
+			Digest:    ociEmptyConfigDigest,
+			Size:      int64(len("{}")),
+		},
+		Layers: []ociDescriptor{layer},
+	}
+}
+
+func (m *ociManifest) taskfileLayerDigest() (string, error) {
+	for _, layer := range m.Layers {
+		if layer.MediaType == ociMediaType {
+			return layer.Digest, nil
+		}
+	}
+	return "", fmt.Errorf("no layer with media type %q found", ociMediaType)
+}
+
+// ociClient is a minimal client for the OCI Distribution Specification. It
+// authenticates requests with credentials sourced from the Docker config
+// and its credHelpers/credsStore keychain helpers, and transparently
+// performs the Bearer token exchange that registries like ghcr.io and
+// Docker Hub require on top of those credentials.
+type ociClient struct {
+	host string
+	http *http.Client
+	auth dockerAuth
+}
+
+func newOCIClient(host string) (*ociClient, error) {
+	authCfg, err := loadDockerAuth(host)
+	if err != nil {
+		return nil, err
+	}
+	return &ociClient{host: host, http: http.DefaultClient, auth: authCfg}, nil
+}
+
+// do sends req, first with the configured basic-auth credential. If the
+// registry challenges with "WWW-Authenticate: Bearer ...", it exchanges the
+// credential for a bearer token at the challenge's realm and retries once
+// with that token instead.
+func (c *ociClient) do(req *http.Request) (*http.Response, error) {
+	c.auth.apply(req)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	challenge, ok := parseBearerChallenge(resp.Header.Get("WWW-Authenticate"))
+	if !ok {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	token, err := fetchBearerToken(c.http, challenge, c.auth)
+	if err != nil {
+		return nil, err
+	}
+
+	retry := req.Clone(req.Context())
+	retry.Header.Set("Authorization", "Bearer "+token)
+	return c.http.Do(retry)
+}
+
+func (c *ociClient) manifest(ctx context.Context, repository, reference string) (*ociManifest, error) {
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", c.host, repository, reference)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", ociManifestMediaType)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry returned %s fetching manifest", resp.Status)
+	}
+
+	var manifest ociManifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+func (c *ociClient) blob(ctx context.Context, repository, digest string) ([]byte, error) {
+	url := fmt.Sprintf("https://%s/v2/%s/blobs/%s", c.host, repository, digest)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry returned %s fetching blob", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,68 @@
+package taskfile
+
+import "testing"
+
+func TestNewOCINodeParsesReference(t *testing.T) {
+	cases := []struct {
+		entrypoint string
+		registry   string
+		repository string
+		reference  string
+	}{
+		{
+			entrypoint: "oci://ghcr.io/acme/taskfiles/backend:v1.2.0",
+			registry:   "ghcr.io",
+			repository: "acme/taskfiles/backend",
+			reference:  "v1.2.0",
+		},
+		{
+			entrypoint: "oci://ghcr.io/acme/taskfiles/backend",
+			registry:   "ghcr.io",
+			repository: "acme/taskfiles/backend",
+			reference:  "latest",
+		},
+		{
+			entrypoint: "oci://ghcr.io/acme/taskfiles/backend@sha256:" + sha256Hex([]byte("x")),
+			registry:   "ghcr.io",
+			repository: "acme/taskfiles/backend",
+			reference:  "sha256:" + sha256Hex([]byte("x")),
+		},
+	}
+
+	for _, c := range cases {
+		node, err := NewOCINode(c.entrypoint)
+		if err != nil {
+			t.Fatalf("NewOCINode(%q): %v", c.entrypoint, err)
+		}
+		if node.registry != c.registry || node.repository != c.repository || node.reference != c.reference {
+			t.Fatalf("NewOCINode(%q) = {%q, %q, %q}, want {%q, %q, %q}",
+				c.entrypoint, node.registry, node.repository, node.reference,
+				c.registry, c.repository, c.reference)
+		}
+	}
+}
+
+func TestNewOCINodeRejectsMissingRepository(t *testing.T) {
+	if _, err := NewOCINode("oci://ghcr.io"); err == nil {
+		t.Fatal("expected an error for an OCI reference with no repository")
+	}
+}
+
+func TestParseOCIRefMatchesNewOCINode(t *testing.T) {
+	const ref = "ghcr.io/acme/taskfiles/backend:v1.2.0"
+
+	host, repository, reference, err := parseOCIRef(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node, err := NewOCINode(ociScheme + ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if host != node.registry || repository != node.repository || reference != node.reference {
+		t.Fatalf("parseOCIRef(%q) = (%q, %q, %q), want (%q, %q, %q) to match NewOCINode",
+			ref, host, repository, reference, node.registry, node.repository, node.reference)
+	}
+}
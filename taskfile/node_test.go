@@ -0,0 +1,53 @@
+package taskfile
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPNodeReadConditional(t *testing.T) {
+	const body = "version: '3'\n"
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	node, err := NewHTTPNode(srv.URL, true, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var _ ConditionalReader = node
+
+	b, notModified, etag, _, err := node.ReadConditional(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	if notModified {
+		t.Fatal("first fetch should not be reported as not-modified")
+	}
+	if string(b) != body {
+		t.Fatalf("body = %q, want %q", b, body)
+	}
+
+	_, notModified, _, _, err = node.ReadConditional(context.Background(), etag, "")
+	if err != nil {
+		t.Fatalf("revalidation: %v", err)
+	}
+	if !notModified {
+		t.Fatal("revalidation with a matching ETag should report not-modified")
+	}
+	if requests != 2 {
+		t.Fatalf("requests = %d, want 2", requests)
+	}
+}
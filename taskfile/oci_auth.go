@@ -0,0 +1,210 @@
+package taskfile
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// dockerAuth is the basic-auth credential for a single registry host,
+// sourced from ~/.docker/config.json, its credHelpers/credsStore, or left
+// empty for registries that allow anonymous pulls.
+type dockerAuth struct {
+	username string
+	password string
+}
+
+// apply sets HTTP basic auth on req, if a credential is configured.
+func (a dockerAuth) apply(req *http.Request) {
+	if a.username == "" && a.password == "" {
+		return
+	}
+	req.SetBasicAuth(a.username, a.password)
+}
+
+// loadDockerAuth resolves the credential for host the same way `docker
+// login`-backed tooling does: a per-registry credential helper
+// (credHelpers), then a static entry in auths, then the global credential
+// store (credsStore). A host with no configured credentials is not an
+// error: the registry may allow anonymous pulls.
+func loadDockerAuth(host string) (dockerAuth, error) {
+	path, err := dockerConfigPath()
+	if err != nil {
+		return dockerAuth{}, err
+	}
+
+	b, err := readFileIfExists(path)
+	if err != nil {
+		return dockerAuth{}, err
+	}
+	if b == nil {
+		return dockerAuth{}, nil
+	}
+
+	var cfg struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+		CredHelpers map[string]string `json:"credHelpers"`
+		CredsStore  string            `json:"credsStore"`
+	}
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return dockerAuth{}, fmt.Errorf("taskfile: malformed docker config at %s: %w", path, err)
+	}
+
+	if helper, ok := cfg.CredHelpers[host]; ok {
+		return execCredentialHelper(helper, host)
+	}
+
+	if entry, ok := cfg.Auths[host]; ok && entry.Auth != "" {
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return dockerAuth{}, fmt.Errorf("taskfile: malformed credentials for %s: %w", host, err)
+		}
+		user, pass, ok := strings.Cut(string(decoded), ":")
+		if !ok {
+			return dockerAuth{}, fmt.Errorf("taskfile: malformed credentials for %s", host)
+		}
+		return dockerAuth{username: user, password: pass}, nil
+	}
+
+	if cfg.CredsStore != "" {
+		return execCredentialHelper(cfg.CredsStore, host)
+	}
+
+	return dockerAuth{}, nil
+}
+
+// execCredentialHelper runs the `docker-credential-<helper>` binary's `get`
+// command, following the protocol documented by Docker's
+// credential-helpers: the server URL is written to stdin, and a JSON object
+// with ServerURL/Username/Secret is read back from stdout.
+func execCredentialHelper(helper, host string) (dockerAuth, error) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(host)
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return dockerAuth{}, fmt.Errorf("taskfile: docker-credential-%s get %s: %w", helper, host, err)
+	}
+
+	var creds struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return dockerAuth{}, fmt.Errorf("taskfile: malformed output from docker-credential-%s: %w", helper, err)
+	}
+	return dockerAuth{username: creds.Username, password: creds.Secret}, nil
+}
+
+// dockerConfigPath returns the location of the user's Docker config,
+// honoring $DOCKER_CONFIG the same way the docker CLI does.
+func dockerConfigPath() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".docker", "config.json"), nil
+}
+
+// readFileIfExists reads path, returning a nil slice (and no error) if it
+// does not exist.
+func readFileIfExists(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return b, err
+}
+
+// bearerChallenge is a parsed "WWW-Authenticate: Bearer ..." header, as
+// returned by token-based registries such as ghcr.io and Docker Hub.
+type bearerChallenge struct {
+	realm   string
+	service string
+	scope   string
+}
+
+// parseBearerChallenge parses the value of a WWW-Authenticate header,
+// returning ok=false if it does not describe a Bearer challenge.
+func parseBearerChallenge(header string) (bearerChallenge, bool) {
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return bearerChallenge{}, false
+	}
+
+	var c bearerChallenge
+	for _, part := range strings.Split(strings.TrimPrefix(header, prefix), ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch key {
+		case "realm":
+			c.realm = value
+		case "service":
+			c.service = value
+		case "scope":
+			c.scope = value
+		}
+	}
+	return c, c.realm != ""
+}
+
+// fetchBearerToken exchanges basic auth credentials for a bearer token at
+// the challenge's realm, as described by the Docker Registry token
+// authentication spec.
+func fetchBearerToken(client *http.Client, c bearerChallenge, basic dockerAuth) (string, error) {
+	u, err := url.Parse(c.realm)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	if c.service != "" {
+		q.Set("service", c.service)
+	}
+	if c.scope != "" {
+		q.Set("scope", c.scope)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	basic.apply(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint %s returned %s", c.realm, resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
@@ -0,0 +1,114 @@
+package taskfile
+
+import (
+	"context"
+	"io"
+)
+
+// ProgressState describes the lifecycle stage of a remote Taskfile fetch
+// reported through a [ProgressFunc].
+type ProgressState int
+
+const (
+	// ProgressQueued is emitted as soon as a remote node is discovered as an
+	// include, before it is fetched.
+	ProgressQueued ProgressState = iota
+	// ProgressStarted is emitted when the fetch for a node begins.
+	ProgressStarted
+	// ProgressUpdated is emitted as bytes are read from a node that reports
+	// its size, allowing callers to render a progress bar.
+	ProgressUpdated
+	// ProgressCached is emitted when a node was served from the local cache
+	// instead of being downloaded.
+	ProgressCached
+	// ProgressDone is emitted once a node has been fully fetched.
+	ProgressDone
+	// ProgressFailed is emitted when fetching a node fails.
+	ProgressFailed
+)
+
+// ProgressEvent reports the state of a single remote node fetch, emitted by
+// a [Reader] configured with [WithProgressFunc].
+type ProgressEvent struct {
+	Location   string
+	State      ProgressState
+	BytesRead  int64
+	TotalBytes int64
+	Err        error
+}
+
+// ProgressFunc is called for every [ProgressEvent] emitted while reading the
+// include graph.
+type ProgressFunc func(ProgressEvent)
+
+// WithProgressFunc sets the function the [Reader] calls as each remote node
+// in the include graph is queued, started, downloaded, cached or fails. This
+// powers `task --prefetch`'s live progress table, but is also useful for any
+// caller that wants feedback on a cold-cache run against a large federation
+// of remote Taskfiles. By default, no progress function is set and fetches
+// are silent.
+func WithProgressFunc(f ProgressFunc) ReaderOption {
+	return &progressFuncOption{f: f}
+}
+
+type progressFuncOption struct {
+	f ProgressFunc
+}
+
+func (o *progressFuncOption) ApplyToReader(r *Reader) {
+	r.progressFunc = o.f
+}
+
+// WithFetchConcurrency bounds how many includes of a single Taskfile are read
+// at once. The bound applies to each Taskfile's includes separately, so
+// nested includes can run more than n fetches at once across the whole
+// include graph. By default, fetches are unbounded and run as fast as the
+// include graph fans out.
+func WithFetchConcurrency(n int) ReaderOption {
+	return &fetchConcurrencyOption{n: n}
+}
+
+type fetchConcurrencyOption struct {
+	n int
+}
+
+func (o *fetchConcurrencyOption) ApplyToReader(r *Reader) {
+	r.fetchConcurrency = o.n
+}
+
+// StreamingNode may be implemented by a [Node] to expose its body as a
+// stream with a known size, so a [Reader] can report byte-level progress as
+// it downloads instead of only a single event once the read completes.
+type StreamingNode interface {
+	ReadStream(ctx context.Context) (r io.ReadCloser, size int64, err error)
+}
+
+func (r *Reader) progress(e ProgressEvent) {
+	if r.progressFunc != nil {
+		r.progressFunc(e)
+	}
+}
+
+// countingReader wraps an [io.Reader], reporting progress to fn as bytes are
+// read from it, similar to Docker's progressreader.
+type countingReader struct {
+	r     io.Reader
+	total int64
+	read  int64
+	fn    func(read, total int64)
+}
+
+func newCountingReader(r io.Reader, total int64, fn func(read, total int64)) *countingReader {
+	return &countingReader{r: r, total: total, fn: fn}
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 {
+		c.read += int64(n)
+		if c.fn != nil {
+			c.fn(c.read, c.total)
+		}
+	}
+	return n, err
+}
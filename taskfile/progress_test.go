@@ -0,0 +1,51 @@
+package taskfile
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReaderReadWithProgressStreamsUpdates(t *testing.T) {
+	const body = "version: '3'\ntasks:\n  foo:\n    cmds:\n      - echo hi\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	node, err := NewHTTPNode(srv.URL, true, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var _ StreamingNode = node
+
+	var events []ProgressEvent
+	r := &Reader{progressFunc: func(e ProgressEvent) { events = append(events, e) }}
+
+	b, err := r.readWithProgress(context.Background(), node)
+	if err != nil {
+		t.Fatalf("readWithProgress: %v", err)
+	}
+	if string(b) != body {
+		t.Fatalf("body = %q, want %q", b, body)
+	}
+
+	var updates int
+	for _, e := range events {
+		if e.State != ProgressUpdated {
+			continue
+		}
+		updates++
+		if e.TotalBytes != int64(len(body)) {
+			t.Fatalf("TotalBytes = %d, want %d", e.TotalBytes, len(body))
+		}
+	}
+	if updates == 0 {
+		t.Fatal("expected at least one ProgressUpdated event from the streaming node")
+	}
+	if events[len(events)-1].BytesRead != int64(len(body)) {
+		t.Fatalf("final BytesRead = %d, want %d", events[len(events)-1].BytesRead, len(body))
+	}
+}
@@ -0,0 +1,154 @@
+package taskfile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Publish pushes the Taskfile at path to ref (e.g.
+// "ghcr.io/acme/taskfiles/backend:v1.2.0") as a single-layer OCI artifact
+// tagged with [ociMediaType], mirroring `task --init` as the counterpart
+// that distributes a Taskfile instead of creating one.
+func Publish(ctx context.Context, path, ref string) error {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	host, repository, reference, err := parseOCIRef(ref)
+	if err != nil {
+		return err
+	}
+
+	client, err := newOCIClient(host)
+	if err != nil {
+		return err
+	}
+
+	// Every manifest needs a config blob, even an unused one; push the
+	// shared empty-config blob alongside the Taskfile layer.
+	if err := client.pushBlob(ctx, repository, []byte("{}")); err != nil {
+		return err
+	}
+
+	layerDigest := "sha256:" + sha256Hex(body)
+	if err := client.pushBlob(ctx, repository, body); err != nil {
+		return err
+	}
+
+	manifest := newOCIManifest(ociDescriptor{
+		MediaType: ociMediaType,
+		Digest:    layerDigest,
+		Size:      int64(len(body)),
+	})
+	return client.pushManifest(ctx, repository, reference, manifest)
+}
+
+// parseOCIRef splits a "<registry>/<repository>[:<tag>|@<digest>]" reference
+// the same way [NewOCINode] does, without requiring the "oci://" scheme
+// prefix, since `task publish`'s target is given as a plain reference.
+func parseOCIRef(ref string) (host, repository, reference string, err error) {
+	ref = strings.TrimPrefix(ref, ociScheme)
+	host, rest, ok := strings.Cut(ref, "/")
+	if !ok {
+		return "", "", "", fmt.Errorf("taskfile: invalid OCI reference %q: missing repository", ref)
+	}
+	repository, reference = rest, "latest"
+	if at := strings.LastIndex(rest, "@"); at != -1 {
+		repository, reference = rest[:at], rest[at+1:]
+	} else if colon := strings.LastIndex(rest, ":"); colon != -1 {
+		repository, reference = rest[:colon], rest[colon+1:]
+	}
+	return host, repository, reference, nil
+}
+
+// pushBlob uploads body as a blob of repository under its sha256 digest. It
+// speaks both halves of the Distribution Spec's upload protocol: a
+// monolithic POST that a registry may complete immediately (201), or a
+// chunked session that the registry opens and expects to be finished with a
+// follow-up PUT (202 plus a Location header to PUT against).
+func (c *ociClient) pushBlob(ctx context.Context, repository string, body []byte) error {
+	digest := "sha256:" + sha256Hex(body)
+
+	startURL := fmt.Sprintf("https://%s/v2/%s/blobs/uploads/?digest=%s", c.host, repository, digest)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, startURL, strings.NewReader(string(body)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		// The registry accepted the monolithic upload outright; nothing
+		// left to do.
+		return nil
+	case http.StatusAccepted:
+		// The registry only opened an upload session; the blob isn't
+		// committed until we PUT it to the session's Location.
+		return c.finishBlobUpload(ctx, resp.Header.Get("Location"), digest, body)
+	default:
+		return fmt.Errorf("registry returned %s pushing blob", resp.Status)
+	}
+}
+
+func (c *ociClient) finishBlobUpload(ctx context.Context, location, digest string, body []byte) error {
+	if location == "" {
+		return fmt.Errorf("registry accepted upload session but returned no Location to finish it")
+	}
+
+	sep := "?"
+	if strings.Contains(location, "?") {
+		sep = "&"
+	}
+	url := location + sep + "digest=" + digest
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, strings.NewReader(string(body)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("registry returned %s finishing blob upload", resp.Status)
+	}
+	return nil
+}
+
+func (c *ociClient) pushManifest(ctx context.Context, repository, reference string, manifest ociManifest) error {
+	b, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", c.host, repository, reference)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, strings.NewReader(string(b)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", ociManifestMediaType)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("registry returned %s pushing manifest", resp.Status)
+	}
+	return nil
+}
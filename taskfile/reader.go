@@ -2,7 +2,9 @@ package taskfile
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
+	"io"
 	"os"
 	"sync"
 	"time"
@@ -14,17 +16,15 @@ import (
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/env"
 	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/internal/i18n"
+	"github.com/go-task/task/v3/internal/logger"
 	"github.com/go-task/task/v3/internal/templater"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
 const (
-	taskfileUntrustedPrompt = `The task you are attempting to run depends on the remote Taskfile at %q.
---- Make sure you trust the source of this Taskfile before continuing ---
-Continue?`
-	taskfileChangedPrompt = `The Taskfile at %q has changed since you last used it!
---- Make sure you trust the source of this Taskfile before continuing ---
-Continue?`
+	taskfileUntrustedPrompt = i18n.KeyTaskfileUntrusted
+	taskfileChangedPrompt   = i18n.KeyTaskfileChanged
 )
 
 type (
@@ -39,16 +39,21 @@ type (
 	// A Reader will recursively read Taskfiles from a given [Node] and build a
 	// [ast.TaskfileGraph] from them.
 	Reader struct {
-		graph       *ast.TaskfileGraph
-		node        Node
-		insecure    bool
-		download    bool
-		offline     bool
-		timeout     time.Duration
-		tempDir     string
-		debugFunc   DebugFunc
-		promptFunc  PromptFunc
-		promptMutex sync.Mutex
+		graph            *ast.TaskfileGraph
+		node             Node
+		insecure         bool
+		download         bool
+		offline          bool
+		timeout          time.Duration
+		tempDir          string
+		keyringDir       string
+		trustedKey       ed25519.PublicKey
+		cacheOpts        []CacheOption
+		progressFunc     ProgressFunc
+		fetchConcurrency int
+		debugFunc        DebugFunc
+		promptFunc       PromptFunc
+		promptMutex      sync.Mutex
 	}
 )
 
@@ -152,6 +157,57 @@ func (o *tempDirOption) ApplyToReader(r *Reader) {
 	r.tempDir = o.tempDir
 }
 
+// WithKeyring enables signature verification of remote Taskfiles. When set,
+// the [Reader] requires a detached signature (a sibling "<location>.sig"
+// file) for every remote node it fetches, and verifies it against a public
+// key pinned under path on a trust-on-first-use basis, like SSH's
+// known_hosts file. Fetches with a missing or invalid signature fail with an
+// [errors.TaskfileSignatureError]. By default, no keyring is set and
+// signature verification is skipped.
+//
+// The first fetch for a given host must be paired with [WithTrustedKey],
+// since the key that gets pinned can never be sourced from the mirror being
+// verified - see [WithTrustedKey] for why.
+func WithKeyring(path string) ReaderOption {
+	return &keyringOption{path: path}
+}
+
+type keyringOption struct {
+	path string
+}
+
+func (o *keyringOption) ApplyToReader(r *Reader) {
+	r.keyringDir = o.path
+}
+
+// WithTrustedKey supplies the out-of-band public key (hex-encoded) used to
+// pin a host's signing key on first use. It must come from the publisher
+// directly - e.g. copied from `task sign`'s output - rather than from the
+// Taskfile or mirror being fetched; a [Reader] that derived trust from the
+// artifact it's verifying would let anyone controlling the mirror supply a
+// matching key alongside a forged body on the very first fetch. Once a key
+// is pinned for a host, subsequent fetches verify against it without needing
+// this option again, unless a different key is supplied, which is treated as
+// a mismatch.
+func WithTrustedKey(hex string) ReaderOption {
+	return &trustedKeyOption{hex: hex}
+}
+
+type trustedKeyOption struct {
+	hex string
+}
+
+func (o *trustedKeyOption) ApplyToReader(r *Reader) {
+	if o.hex == "" {
+		return
+	}
+	key, err := decodePublicKey(o.hex)
+	if err != nil {
+		return
+	}
+	r.trustedKey = key
+}
+
 // WithDebugFunc sets the debug function to be used by the [Reader]. If set,
 // this function will be called with debug messages. This can be useful if the
 // caller wants to log debug messages from the [Reader]. By default, no debug
@@ -168,6 +224,25 @@ func (o *debugFuncOption) ApplyToReader(r *Reader) {
 	r.debugFunc = o.debugFunc
 }
 
+// WithLogger wires the [Reader]'s debug messages through l as structured
+// debug-level events, instead of requiring a separate [WithDebugFunc]. This
+// is the preferred way to observe a [Reader]'s progress, since it also
+// carries the message through to any structured sink (text/json/logfmt)
+// attached to l.
+func WithLogger(l *logger.Logger) ReaderOption {
+	return &loggerOption{logger: l}
+}
+
+type loggerOption struct {
+	logger *logger.Logger
+}
+
+func (o *loggerOption) ApplyToReader(r *Reader) {
+	r.debugFunc = func(msg string) {
+		o.logger.Log(logger.DebugLevel, msg, nil)
+	}
+}
+
 // WithPromptFunc sets the prompt function to be used by the [Reader]. If set,
 // this function will be called with prompt messages. The function should
 // optionally log the message to the user and return nil if the prompt is
@@ -206,7 +281,7 @@ func (r *Reader) debugf(format string, a ...any) {
 
 func (r *Reader) promptf(format string, a ...any) error {
 	if r.promptFunc != nil {
-		return r.promptFunc(fmt.Sprintf(format, a...))
+		return r.promptFunc(i18n.T(format, a...))
 	}
 	return nil
 }
@@ -234,8 +309,13 @@ func (r *Reader) include(node Node) error {
 		return err
 	}
 
-	// Create an error group to wait for all included Taskfiles to be read
+	// Create an error group to wait for all included Taskfiles to be read,
+	// optionally bounding how many of this Taskfile's includes are read at
+	// once.
 	var g errgroup.Group
+	if r.fetchConcurrency > 0 {
+		g.SetLimit(r.fetchConcurrency)
+	}
 
 	// Loop over each included taskfile
 	for _, include := range vertex.Taskfile.Includes.All() {
@@ -273,6 +353,9 @@ func (r *Reader) include(node Node) error {
 			includeNode, err := NewNode(entrypoint, include.Dir, r.insecure, r.timeout,
 				WithParent(node),
 			)
+			if err == nil && includeNode.Remote() {
+				r.progress(ProgressEvent{Location: includeNode.Location(), State: ProgressQueued})
+			}
 			if err != nil {
 				if include.Optional {
 					return nil
@@ -363,6 +446,45 @@ func (r *Reader) readNode(node Node) (*ast.Taskfile, error) {
 	return &tf, nil
 }
 
+// verifySignature fetches the detached signature that should sit alongside
+// node and verifies body against it using the [Reader]'s keyring.
+func (r *Reader) verifySignature(ctx context.Context, node Node, body []byte) error {
+	sigNode, err := NewNode(sigLocation(node.Location()), "", r.insecure, r.timeout,
+		WithParent(node),
+	)
+	if err != nil {
+		return &errors.TaskfileSignatureError{URI: node.Location(), Err: err}
+	}
+
+	sig, err := sigNode.Read(ctx)
+	if err != nil {
+		return &errors.TaskfileSignatureError{URI: node.Location(), Err: err}
+	}
+
+	return newKeyring(r.keyringDir).verify(node.Location(), body, sig, r.trustedKey)
+}
+
+// readWithProgress reads node's body, reporting byte-level progress events
+// when node implements [StreamingNode]. Otherwise, it falls back to a single
+// node.Read and reports no progress events of its own.
+func (r *Reader) readWithProgress(ctx context.Context, node Node) ([]byte, error) {
+	streaming, ok := node.(StreamingNode)
+	if !ok {
+		return node.Read(ctx)
+	}
+
+	stream, size, err := streaming.ReadStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	counted := newCountingReader(stream, size, func(read, total int64) {
+		r.progress(ProgressEvent{Location: node.Location(), State: ProgressUpdated, BytesRead: read, TotalBytes: total})
+	})
+	return io.ReadAll(counted)
+}
+
 func (r *Reader) loadNodeContent(node Node) ([]byte, error) {
 	if !node.Remote() {
 		ctx, cf := context.WithTimeout(context.Background(), r.timeout)
@@ -370,7 +492,7 @@ func (r *Reader) loadNodeContent(node Node) ([]byte, error) {
 		return node.Read(ctx)
 	}
 
-	cache, err := NewCache(r.tempDir)
+	cache, err := NewCache(r.tempDir, r.cacheOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -379,42 +501,104 @@ func (r *Reader) loadNodeContent(node Node) ([]byte, error) {
 		// In offline mode try to use cached copy
 		cached, err := cache.read(node)
 		if errors.Is(err, os.ErrNotExist) {
+			r.progress(ProgressEvent{Location: node.Location(), State: ProgressFailed, Err: err})
 			return nil, &errors.TaskfileCacheNotFoundError{URI: node.Location()}
 		} else if err != nil {
+			r.progress(ProgressEvent{Location: node.Location(), State: ProgressFailed, Err: err})
 			return nil, err
 		}
 		r.debugf("task: [%s] Fetched cached copy\n", node.Location())
+		r.progress(ProgressEvent{Location: node.Location(), State: ProgressCached, BytesRead: int64(len(cached)), TotalBytes: int64(len(cached))})
 
 		return cached, nil
 	}
 
+	r.progress(ProgressEvent{Location: node.Location(), State: ProgressStarted})
+
 	ctx, cf := context.WithTimeout(context.Background(), r.timeout)
 	defer cf()
 
-	b, err := node.Read(ctx)
+	// If the node supports conditional requests and we already have a cached
+	// copy, revalidate with If-None-Match/If-Modified-Since instead of
+	// unconditionally re-downloading the body.
+	if condNode, ok := node.(ConditionalReader); ok {
+		if etag, lastModified, ok := cache.revalidationInfo(node); ok {
+			body, notModified, newETag, newLastModified, err := condNode.ReadConditional(ctx, etag, lastModified)
+			if err == nil && notModified {
+				r.debugf("task: [%s] Not modified. Reusing cached copy\n", node.Location())
+				if err := cache.touch(node); err != nil {
+					return nil, err
+				}
+				cached, err := cache.read(node)
+				if err != nil {
+					return nil, err
+				}
+				r.progress(ProgressEvent{Location: node.Location(), State: ProgressDone, BytesRead: int64(len(cached)), TotalBytes: int64(len(cached))})
+				return cached, nil
+			} else if err == nil {
+				// The remote copy changed since it was last cached. It is
+				// not yet trusted just because it revalidated successfully:
+				// run it through the same signature/checksum-prompt gate as
+				// a normal fetch before accepting it.
+				r.debugf("task: [%s] Fetched remote copy\n", node.Location())
+				r.progress(ProgressEvent{Location: node.Location(), State: ProgressDone, BytesRead: int64(len(body)), TotalBytes: int64(len(body))})
+				return r.verifyAndCache(ctx, node, cache, body, newETag, newLastModified)
+			}
+		}
+	}
+
+	b, err := r.readWithProgress(ctx, node)
 	if errors.Is(err, &errors.TaskfileNetworkTimeoutError{}) {
 		// If we timed out then we likely have a network issue
 
 		// If a download was requested, then we can't use a cached copy
 		if r.download {
+			r.progress(ProgressEvent{Location: node.Location(), State: ProgressFailed, Err: err})
 			return nil, &errors.TaskfileNetworkTimeoutError{URI: node.Location(), Timeout: r.timeout}
 		}
 
 		// Search for any cached copies
 		cached, err := cache.read(node)
 		if errors.Is(err, os.ErrNotExist) {
+			r.progress(ProgressEvent{Location: node.Location(), State: ProgressFailed, Err: err})
 			return nil, &errors.TaskfileNetworkTimeoutError{URI: node.Location(), Timeout: r.timeout, CheckedCache: true}
 		} else if err != nil {
+			r.progress(ProgressEvent{Location: node.Location(), State: ProgressFailed, Err: err})
 			return nil, err
 		}
 		r.debugf("task: [%s] Network timeout. Fetched cached copy\n", node.Location())
+		r.progress(ProgressEvent{Location: node.Location(), State: ProgressCached, BytesRead: int64(len(cached)), TotalBytes: int64(len(cached))})
 
 		return cached, nil
 
 	} else if err != nil {
+		r.progress(ProgressEvent{Location: node.Location(), State: ProgressFailed, Err: err})
 		return nil, err
 	}
 	r.debugf("task: [%s] Fetched remote copy\n", node.Location())
+	r.progress(ProgressEvent{Location: node.Location(), State: ProgressDone, BytesRead: int64(len(b)), TotalBytes: int64(len(b))})
+
+	return r.verifyAndCache(ctx, node, cache, b, "", "")
+}
+
+// verifyAndCache runs a freshly fetched body through the [Reader]'s trust
+// gate before it is handed back to the caller: signature verification if a
+// keyring is configured, otherwise the checksum-prompt flow. Every path that
+// accepts a remote body not already known to be trusted - whether from a
+// normal fetch or a changed body found during conditional revalidation -
+// must funnel through here rather than returning it directly.
+func (r *Reader) verifyAndCache(ctx context.Context, node Node, cache *Cache, b []byte, etag, lastModified string) ([]byte, error) {
+	// If a keyring is configured, verify the node's signature instead of
+	// falling back to the checksum-prompt flow below. This gives remote
+	// Taskfiles a real chain of trust instead of a "did this change"
+	// heuristic.
+	if r.keyringDir != "" {
+		if err := r.verifySignature(ctx, node, b); err != nil {
+			return nil, err
+		}
+		r.debugf("task: [%s] Signature verified\n", node.Location())
+		return b, nil
+	}
 
 	// Get the checksums
 	checksum := checksum(b)
@@ -438,14 +622,9 @@ func (r *Reader) loadNodeContent(node Node) ([]byte, error) {
 			return nil, &errors.TaskfileNotTrustedError{URI: node.Location()}
 		}
 
-		// Store the checksum
-		if err := cache.writeChecksum(node, checksum); err != nil {
-			return nil, err
-		}
-
 		// Cache the file
 		r.debugf("task: [%s] Caching downloaded file\n", node.Location())
-		if err = cache.write(node, b); err != nil {
+		if err := cache.write(node, b, etag, lastModified); err != nil {
 			return nil, err
 		}
 	}
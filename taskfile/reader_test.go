@@ -0,0 +1,72 @@
+package taskfile
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestLoadNodeContentRevalidatesChangedBodyThroughTrustGate proves that a
+// Taskfile revalidated as "changed" (a 200, not a 304) is still routed
+// through the same checksum-prompt trust gate as a normal fetch, instead of
+// being accepted outright - a mirror that mutates the file after first
+// trust must not be able to bypass the prompt.
+func TestLoadNodeContentRevalidatesChangedBodyThroughTrustGate(t *testing.T) {
+	const (
+		bodyV1 = "version: '3'\ntasks:\n  foo:\n    cmds:\n      - echo v1\n"
+		bodyV2 = "version: '3'\ntasks:\n  foo:\n    cmds:\n      - echo v2-from-a-tampered-mirror\n"
+	)
+
+	etag := `"v1"`
+	body := bodyV1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	node, err := NewHTTPNode(srv.URL, true, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prompts := 0
+	r := NewReader(node,
+		WithTempDir(t.TempDir()),
+		WithPromptFunc(func(msg string) error {
+			prompts++
+			if prompts > 1 {
+				return fmt.Errorf("user declined to trust the changed Taskfile")
+			}
+			return nil
+		}),
+	)
+
+	// First fetch: nothing cached yet, the untrusted-on-first-sight prompt
+	// fires and is accepted, seeding the cache with bodyV1's checksum/ETag.
+	b, err := r.loadNodeContent(node)
+	if err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	if string(b) != bodyV1 {
+		t.Fatalf("first fetch body = %q, want %q", b, bodyV1)
+	}
+
+	// The mirror now serves a different body under a new ETag, as if it had
+	// been tampered with after the Taskfile was first trusted.
+	etag = `"v2"`
+	body = bodyV2
+
+	if _, err := r.loadNodeContent(node); err == nil {
+		t.Fatal("expected the changed body found during revalidation to be rejected by the trust prompt")
+	}
+	if prompts != 2 {
+		t.Fatalf("prompts = %d, want 2: the changed-body revalidation must go through the checksum-changed prompt, not bypass it", prompts)
+	}
+}
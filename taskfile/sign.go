@@ -0,0 +1,52 @@
+package taskfile
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+)
+
+// Sign creates a detached signature for the Taskfile at path and writes it
+// alongside the file as "<path>.sig", returning the signature's path and the
+// hex-encoded public key that verifies it. If key is empty, a new ed25519
+// keypair is generated for the signature; otherwise key is used as a
+// hex-encoded ed25519 private key.
+//
+// Unlike the signature file, the public key is not embedded anywhere a
+// verifier fetches it from automatically: it must be handed to whoever
+// verifies out-of-band (e.g. via --trusted-key) and pinned from there, so
+// that trust never comes from the same mirror serving the artifact itself.
+func Sign(path, key string) (sigPath, publicKey string, err error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	var priv ed25519.PrivateKey
+	if key == "" {
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", "", err
+		}
+	} else {
+		decoded, err := hex.DecodeString(key)
+		if err != nil {
+			return "", "", err
+		}
+		if len(decoded) != ed25519.PrivateKeySize {
+			return "", "", fmt.Errorf("taskfile: signing key has invalid length %d, want %d", len(decoded), ed25519.PrivateKeySize)
+		}
+		priv = ed25519.PrivateKey(decoded)
+	}
+
+	sig := ed25519.Sign(priv, b)
+	pub := priv.Public().(ed25519.PublicKey)
+
+	sigPath = path + ".sig"
+	if err := os.WriteFile(sigPath, []byte(hex.EncodeToString(sig)+"\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	return sigPath, encodePublicKey(pub), nil
+}